@@ -17,6 +17,7 @@ package githubrepo
 import (
 	"fmt"
 	"net/url"
+	"regexp"
 	"strings"
 
 	"github.com/ossf/scorecard/v4/clients"
@@ -25,29 +26,82 @@ import (
 
 const (
 	githubOrgRepo = ".github"
+	githubHost    = "github.com"
+	// githubDevHost is the web editor, which serves the same owner/repo
+	// paths as github.com.
+	githubDevHost = "github.dev"
+	// ghcrHost is GitHub's container registry.
+	ghcrHost = "ghcr.io"
+	// githubRepoNameMaxLen is the longest repository name GitHub accepts.
+	githubRepoNameMaxLen = 100
 )
 
+// maxHostSuggestionDistance is the largest edit distance at which
+// SuggestHost still considers a host a likely typo.
+const maxHostSuggestionDistance = 1
+
+// knownHosts are the hosts SuggestHost may suggest.
+var knownHosts = []string{githubHost}
+
+// typoTLDs are top-level domains commonly typed in place of a known host's,
+// e.g. "github.org". Other TLDs are not suggested for, since hosts such as
+// github.io or an Enterprise github.<corp> host are real.
+var typoTLDs = []string{"org", "net"}
+
+// zeroWidthRemover strips the zero width space and byte order mark.
+var zeroWidthRemover = strings.NewReplacer("\u200b", "", "\ufeff", "")
+
+// githubShorthands are config-file prefixes such as "github:owner/repo"
+// which expand to github.com.
+var githubShorthands = []string{"github:", "gh:"}
+
+// githubOwnerRe matches the characters GitHub allows in user and
+// organization names.
+var githubOwnerRe = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+var githubRepoNameRe = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// goModuleMajorRe matches the major version suffix of a Go module path,
+// e.g. the "v2" in github.com/owner/repo/v2.
+var goModuleMajorRe = regexp.MustCompile(`^v([2-9]|[1-9][0-9]+)$`)
+
 type repoURL struct {
 	host, owner, repo, defaultBranch, commitSHA string
 	metadata                                    []string
 }
 
 // Parses input string into repoURL struct.
-// Accepts "owner/repo" or "github.com/owner/repo".
+// Accepts "owner/repo", "github:owner/repo" or "github.com/owner/repo".
 func (r *repoURL) parse(input string) error {
 	var t string
 
+	// Copy-pasted input often carries surrounding whitespace or invisible
+	// zero-width characters.
+	input = strings.TrimSpace(zeroWidthRemover.Replace(input))
+
+	for _, prefix := range githubShorthands {
+		if strings.HasPrefix(input, prefix) {
+			input = githubHost + "/" + strings.TrimPrefix(input, prefix)
+			break
+		}
+	}
+
 	const two = 2
-	const three = 3
 
-	c := strings.Split(input, "/")
+	// Inputs with a scheme are full URLs. Only scheme-less inputs are split
+	// here, so the "//" of "://" is never collapsed into a separator.
+	var c []string
+	if !strings.Contains(input, "://") {
+		c = splitPath(input)
+	}
 
 	switch l := len(c); {
 	// This will takes care for repo/owner format.
-	// By default it will use github.com
-	case l == two:
-		t = "github.com/" + c[0] + "/" + c[1]
-	case l >= three:
+	// By default it will use github.com. Owners cannot contain a ".",
+	// so "host/owner" is left for the missing repo name check below.
+	case l == two && !strings.Contains(c[0], "."):
+		t = githubHost + "/" + c[0] + "/" + c[1]
+	default:
 		t = input
 	}
 
@@ -58,22 +112,108 @@ func (r *repoURL) parse(input string) error {
 
 	u, e := url.Parse(t)
 	if e != nil {
-		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("url.Parse: %v", e))
+		return sce.WithMessage(sce.ErrorInvalidURL, fmt.Sprintf("url.Parse: %v", e))
 	}
 
-	const splitLen = 2
-	split := strings.SplitN(strings.Trim(u.Path, "/"), "/", splitLen)
-	if len(split) != splitLen {
+	// Split on the escaped path so that an encoded "/" (%2F) stays within
+	// its component, then decode each component separately. url.Parse has
+	// already rejected malformed escapes, so decoding cannot fail.
+	// Only the first two components identify the repo; anything after them,
+	// such as web UI paths like /blob/main/file.go or /-/tree/main, is dropped.
+	const minLen = 2
+	split := splitPath(u.EscapedPath())
+	// A single component after an empty one (e.g. "github.com//repo") is a
+	// missing owner, not a missing repo.
+	if len(split) == 1 && !strings.HasPrefix(u.EscapedPath(), "//") {
+		return sce.WithMessage(sce.ErrorMissingRepoName,
+			fmt.Sprintf("%v looks like a user or organization. Append /<repo> to the url", input))
+	}
+	if len(split) < minLen {
 		return sce.WithMessage(sce.ErrorInvalidURL, fmt.Sprintf("%v. Exepted full repository url", input))
 	}
 
-	r.host, r.owner, r.repo = u.Host, split[0], split[1]
+	owner, _ := url.PathUnescape(split[0])
+	repo, _ := url.PathUnescape(split[1])
+	// The owner is passed unescaped to the GitHub API, so a decoded "/" or
+	// ".." must not get through.
+	if !githubOwnerRe.MatchString(owner) {
+		return sce.WithMessage(sce.ErrorInvalidGithubOwner, owner)
+	}
+
+	// Go module paths for major versions >= 2 end in /vN after the repo.
+	if len(split) > minLen && goModuleMajorRe.MatchString(split[minLen]) {
+		r.AppendMetadata("module-major=" + split[minLen])
+	}
+
+	// Hosts are case-insensitive. Browser address bars often carry a "www."
+	// prefix; other subdomains (e.g. api. or gist.) are distinct hosts and
+	// are left untouched.
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	switch host {
+	case githubDevHost:
+		host = githubHost
+	case ghcrHost:
+		// Container images published to ghcr.io are built from the github.com
+		// repo of the same owner/name. The image tag and digest are kept as
+		// metadata. Nested image names do not map to a single repo.
+		var metadata []string
+		var ok bool
+		if len(split) == minLen {
+			repo, metadata, ok = splitImageRef(repo)
+		}
+		if !ok {
+			return sce.WithMessage(sce.ErrorInvalidURL,
+				fmt.Sprintf("%v. Expected ghcr.io/owner/repo[:tag][@digest]", input))
+		}
+		host = githubHost
+		r.AppendMetadata(metadata...)
+	}
+
+	r.host, r.owner, r.repo = host, owner, repo
 	return nil
 }
 
+// splitImageRef splits a container image name of the form
+// name[:tag][@algorithm:digest] into the name and its tag and digest
+// metadata. It returns false for an empty name, tag or digest.
+func splitImageRef(ref string) (string, []string, bool) {
+	var tag, digest string
+	if i := strings.Index(ref, "@"); i >= 0 {
+		ref, digest = ref[:i], ref[i+1:]
+		const digestParts = 2
+		parts := strings.SplitN(digest, ":", digestParts)
+		if len(parts) != digestParts || parts[0] == "" || parts[1] == "" {
+			return "", nil, false
+		}
+	}
+	if i := strings.LastIndex(ref, ":"); i >= 0 {
+		ref, tag = ref[:i], ref[i+1:]
+		if tag == "" {
+			return "", nil, false
+		}
+	}
+	if ref == "" {
+		return "", nil, false
+	}
+
+	var metadata []string
+	if tag != "" {
+		metadata = append(metadata, "imageTag="+tag)
+	}
+	if digest != "" {
+		metadata = append(metadata, "imageDigest="+digest)
+	}
+	return ref, metadata, true
+}
+
+// splitPath splits p on "/", collapsing repeated and surrounding slashes.
+func splitPath(p string) []string {
+	return strings.FieldsFunc(p, func(c rune) bool { return c == '/' })
+}
+
 // URI implements Repo.URI().
 func (r *repoURL) URI() string {
-	return fmt.Sprintf("%s/%s/%s", r.host, r.owner, r.repo)
+	return fmt.Sprintf("%s/%s/%s", r.host, url.PathEscape(r.owner), url.PathEscape(r.repo))
 }
 
 // String implements Repo.String.
@@ -93,8 +233,11 @@ func (r *repoURL) Org() clients.Repo {
 // IsValid implements Repo.IsValid.
 func (r *repoURL) IsValid() error {
 	switch r.host {
-	case "github.com":
+	case githubHost:
 	default:
+		if suggestion, ok := SuggestHost(r.host); ok {
+			return sce.WithMessage(sce.ErrorUnsupportedHost, fmt.Sprintf("%s. Did you mean %s?", r.host, suggestion))
+		}
 		return sce.WithMessage(sce.ErrorUnsupportedHost, r.host)
 	}
 
@@ -102,6 +245,11 @@ func (r *repoURL) IsValid() error {
 		return sce.WithMessage(sce.ErrorInvalidURL,
 			fmt.Sprintf("%v. Expected the full reposiroty url", r.URI()))
 	}
+
+	if len(r.repo) > githubRepoNameMaxLen || r.repo == "." || r.repo == ".." ||
+		!githubRepoNameRe.MatchString(r.repo) {
+		return sce.WithMessage(sce.ErrorInvalidGithubRepoName, r.repo)
+	}
 	return nil
 }
 
@@ -116,6 +264,9 @@ func (r *repoURL) Metadata() []string {
 
 // MakeGithubRepo takes input of form "owner/repo" or "github.com/owner/repo"
 // and returns an implementation of clients.Repo interface.
+// ghcr.io image references record their tag and digest in Metadata() as
+// "imageTag=<tag>" and "imageDigest=<digest>", and Go module paths ending
+// in /vN record "module-major=vN".
 func MakeGithubRepo(input string) (clients.Repo, error) {
 	var repo repoURL
 	if err := repo.parse(input); err != nil {
@@ -126,3 +277,72 @@ func MakeGithubRepo(input string) (clients.Repo, error) {
 	}
 	return &repo, nil
 }
+
+// SuggestHost returns the supported host that the input most likely
+// meant, e.g. "github.com" for "github.con" or "github.org". It suggests
+// hosts one edit away from the input, or with the same name under one of
+// typoTLDs, and returns false for exact matches and any other host.
+func SuggestHost(host string) (string, bool) {
+	host = strings.ToLower(host)
+	name, tld := splitTLD(host)
+	for _, known := range knownHosts {
+		if host == known {
+			continue
+		}
+		if levenshtein(host, known) <= maxHostSuggestionDistance {
+			return known, true
+		}
+		if knownName, _ := splitTLD(known); name == knownName && contains(typoTLDs, tld) {
+			return known, true
+		}
+	}
+	return "", false
+}
+
+// splitTLD splits host into the part before its last label and that label,
+// e.g. "github" and "com" for "github.com".
+func splitTLD(host string) (string, string) {
+	i := strings.LastIndex(host, ".")
+	if i < 0 {
+		return host, ""
+	}
+	return host[:i], host[i+1:]
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func minInt(x int, ys ...int) int {
+	for _, y := range ys {
+		if y < x {
+			x = y
+		}
+	}
+	return x
+}
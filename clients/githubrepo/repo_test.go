@@ -15,18 +15,24 @@
 package githubrepo
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+
+	sce "github.com/ossf/scorecard/v4/errors"
 )
 
 func TestRepoURL_IsValid(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
-		name     string
-		inputURL string
-		expected repoURL
-		wantErr  bool
+		name      string
+		inputURL  string
+		expected  repoURL
+		uri       string
+		wantErr   bool
+		wantErrIs error
 	}{
 		{
 			name: "Valid http address",
@@ -78,6 +84,321 @@ func TestRepoURL_IsValid(t *testing.T) {
 			inputURL: "https://github.com/foo/kubeflow",
 			wantErr:  false,
 		},
+		{
+			name: "github.dev web editor",
+			expected: repoURL{
+				host:  "github.com",
+				owner: "foo",
+				repo:  "kubeflow",
+			},
+			inputURL: "https://github.dev/foo/kubeflow",
+			wantErr:  false,
+		},
+		{
+			name: "www prefix",
+			expected: repoURL{
+				host:  "github.com",
+				owner: "foo",
+				repo:  "kubeflow",
+			},
+			inputURL: "www.github.com/foo/kubeflow",
+			wantErr:  false,
+		},
+		{
+			name: "github shorthand",
+			expected: repoURL{
+				host:  "github.com",
+				owner: "foo",
+				repo:  "kubeflow",
+			},
+			inputURL: "github:foo/kubeflow",
+			wantErr:  false,
+		},
+		{
+			name: "gh shorthand",
+			expected: repoURL{
+				host:  "github.com",
+				owner: "foo",
+				repo:  "kubeflow",
+			},
+			inputURL: "gh:foo/kubeflow",
+			wantErr:  false,
+		},
+		{
+			name: "Upper case host",
+			expected: repoURL{
+				host:  "github.com",
+				owner: "foo",
+				repo:  "kubeflow",
+			},
+			inputURL: "https://GitHub.COM/foo/kubeflow",
+			wantErr:  false,
+		},
+		{
+			name: "Surrounding spaces",
+			expected: repoURL{
+				host:  "github.com",
+				owner: "foo",
+				repo:  "kubeflow",
+			},
+			inputURL: "  https://github.com/foo/kubeflow\t ",
+			wantErr:  false,
+		},
+		{
+			name: "Trailing newline",
+			expected: repoURL{
+				host:  "github.com",
+				owner: "foo",
+				repo:  "kubeflow",
+			},
+			inputURL: "github.com/foo/kubeflow\n",
+			wantErr:  false,
+		},
+		{
+			name: "Zero width space",
+			expected: repoURL{
+				host:  "github.com",
+				owner: "foo",
+				repo:  "kubeflow",
+			},
+			inputURL: "github.com\u200b/foo/kubeflow",
+			wantErr:  false,
+		},
+		{
+			name: "Duplicate slashes",
+			expected: repoURL{
+				host:  "github.com",
+				owner: "foo",
+				repo:  "kubeflow",
+			},
+			inputURL: "github.com//foo//kubeflow",
+			wantErr:  false,
+		},
+		{
+			name: "Duplicate slashes in owner/repo",
+			expected: repoURL{
+				host:  "github.com",
+				owner: "foo",
+				repo:  "kubeflow",
+			},
+			inputURL: "foo//kubeflow",
+			wantErr:  false,
+		},
+		{
+			name: "api subdomain",
+			expected: repoURL{
+				host:  "api.github.com",
+				owner: "foo",
+				repo:  "kubeflow",
+			},
+			inputURL: "api.github.com/foo/kubeflow",
+			wantErr:  true,
+		},
+		{
+			name:      "Encoded slash in owner",
+			inputURL:  "github.com/foo%2Fx/bar",
+			wantErrIs: sce.ErrorInvalidGithubOwner,
+		},
+		{
+			name:      "Path traversal in owner",
+			inputURL:  "github.com/a%2F..%2F..%2Fusers/bar",
+			wantErrIs: sce.ErrorInvalidGithubOwner,
+		},
+		{
+			name: "Go module major version suffix",
+			expected: repoURL{
+				host:     "github.com",
+				owner:    "foo",
+				repo:     "bar",
+				metadata: []string{"module-major=v2"},
+			},
+			inputURL: "github.com/foo/bar/v2",
+			wantErr:  false,
+		},
+		{
+			name: "Go module pre-release is not a major version",
+			expected: repoURL{
+				host:  "github.com",
+				owner: "foo",
+				repo:  "bar",
+			},
+			inputURL: "github.com/foo/bar/v2beta",
+			wantErr:  false,
+		},
+		{
+			name: "Go module subdirectory",
+			expected: repoURL{
+				host:  "github.com",
+				owner: "foo",
+				repo:  "bar",
+			},
+			inputURL: "github.com/foo/bar/internal",
+			wantErr:  false,
+		},
+		{
+			name:      "Host only",
+			inputURL:  "https://github.com",
+			wantErrIs: sce.ErrorInvalidURL,
+		},
+		{
+			name:      "Host only with trailing slash",
+			inputURL:  "https://github.com/",
+			wantErrIs: sce.ErrorInvalidURL,
+		},
+		{
+			name:      "Scheme and host only",
+			inputURL:  "http://foo",
+			wantErrIs: sce.ErrorInvalidURL,
+		},
+		{
+			name:      "Missing owner",
+			inputURL:  "github.com//repo3",
+			wantErrIs: sce.ErrorInvalidURL,
+		},
+		{
+			name: "Image with tag",
+			expected: repoURL{
+				host:     "github.com",
+				owner:    "foo",
+				repo:     "bar",
+				metadata: []string{"imageTag=latest"},
+			},
+			inputURL: "ghcr.io/foo/bar:latest",
+			wantErr:  false,
+		},
+		{
+			name: "Image without tag",
+			expected: repoURL{
+				host:  "github.com",
+				owner: "foo",
+				repo:  "bar",
+			},
+			inputURL: "ghcr.io/foo/bar",
+			wantErr:  false,
+		},
+		{
+			name: "Image with digest",
+			expected: repoURL{
+				host:     "github.com",
+				owner:    "foo",
+				repo:     "bar",
+				metadata: []string{"imageDigest=sha256:abcd"},
+			},
+			inputURL: "ghcr.io/foo/bar@sha256:abcd",
+			wantErr:  false,
+		},
+		{
+			name: "Image with tag and digest",
+			expected: repoURL{
+				host:     "github.com",
+				owner:    "foo",
+				repo:     "bar",
+				metadata: []string{"imageTag=1", "imageDigest=sha256:abcd"},
+			},
+			inputURL: "ghcr.io/foo/bar:1@sha256:abcd",
+			wantErr:  false,
+		},
+		{
+			name:      "Image without repo",
+			inputURL:  "ghcr.io/foo",
+			wantErrIs: sce.ErrorMissingRepoName,
+		},
+		{
+			name:      "Image with empty tag",
+			inputURL:  "ghcr.io/foo/bar:",
+			wantErrIs: sce.ErrorInvalidURL,
+		},
+		{
+			name:      "Image with empty digest",
+			inputURL:  "ghcr.io/foo/bar@sha256:",
+			wantErrIs: sce.ErrorInvalidURL,
+		},
+		{
+			name:      "Nested image",
+			inputURL:  "ghcr.io/foo/bar/baz:1",
+			wantErrIs: sce.ErrorInvalidURL,
+		},
+		{
+			name: "GitHub blob",
+			expected: repoURL{
+				host:  "github.com",
+				owner: "foo",
+				repo:  "bar",
+			},
+			inputURL: "https://github.com/foo/bar/blob/main/file.go",
+			uri:      "github.com/foo/bar",
+			wantErr:  false,
+		},
+		{
+			name: "GitHub tree",
+			expected: repoURL{
+				host:  "github.com",
+				owner: "foo",
+				repo:  "bar",
+			},
+			inputURL: "github.com/foo/bar/tree/main",
+			uri:      "github.com/foo/bar",
+			wantErr:  false,
+		},
+		{
+			name: "GitLab tree",
+			expected: repoURL{
+				host:  "gitlab.com",
+				owner: "foo",
+				repo:  "bar",
+			},
+			inputURL: "https://gitlab.com/foo/bar/-/tree/main",
+			uri:      "gitlab.com/foo/bar",
+			wantErr:  true,
+		},
+		{
+			name: "GitLab blob",
+			expected: repoURL{
+				host:  "gitlab.com",
+				owner: "foo",
+				repo:  "bar",
+			},
+			inputURL: "https://gitlab.com/foo/bar/-/blob/main/README.md",
+			uri:      "gitlab.com/foo/bar",
+			wantErr:  true,
+		},
+		{
+			name:      "Profile url",
+			inputURL:  "https://github.com/torvalds",
+			wantErrIs: sce.ErrorMissingRepoName,
+		},
+		{
+			name:      "Profile url without scheme",
+			inputURL:  "github.com/torvalds",
+			wantErrIs: sce.ErrorMissingRepoName,
+		},
+		{
+			name: "Space in repo name",
+			expected: repoURL{
+				host:  "github.com",
+				owner: "foo",
+				repo:  "my repo",
+			},
+			inputURL: "github.com/foo/my%20repo",
+			uri:      "github.com/foo/my%20repo",
+			wantErr:  true,
+		},
+		{
+			name: "Encoded slash stays within repo",
+			expected: repoURL{
+				host:  "github.com",
+				owner: "foo",
+				repo:  "bar/baz",
+			},
+			inputURL: "https://github.com/foo/bar%2Fbaz",
+			uri:      "github.com/foo/bar%2Fbaz",
+			wantErr:  true,
+		},
+		{
+			name:      "Malformed escape",
+			inputURL:  "https://github.com/foo/bar%zz",
+			wantErrIs: sce.ErrorInvalidURL,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt // Re-initializing variable so it is not changed while executing the closure below
@@ -88,14 +409,127 @@ func TestRepoURL_IsValid(t *testing.T) {
 				owner: tt.expected.owner,
 				repo:  tt.expected.repo,
 			}
-			if err := r.parse(tt.inputURL); err != nil {
+			err := r.parse(tt.inputURL)
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("repoURL.parse() error = %v, wantErrIs %v", err, tt.wantErrIs)
+				}
+				// ErrorMissingRepoName must keep matching ErrorInvalidURL, and
+				// must only be reported when expected.
+				wantMissingRepo := errors.Is(tt.wantErrIs, sce.ErrorMissingRepoName)
+				if errors.Is(err, sce.ErrorMissingRepoName) != wantMissingRepo ||
+					(wantMissingRepo && !errors.Is(err, sce.ErrorInvalidURL)) {
+					t.Errorf("repoURL.parse() error = %v, wantErrIs %v", err, tt.wantErrIs)
+				}
+				return
+			}
+			if err != nil {
 				t.Errorf("repoURL.parse() error = %v", err)
 			}
 			if err := r.IsValid(); (err != nil) != tt.wantErr {
 				t.Errorf("repoURL.IsValid() error = %v, wantErr %v", err, tt.wantErr)
 			}
-			if !tt.wantErr && !cmp.Equal(tt.expected, r, cmp.AllowUnexported(repoURL{})) {
-				t.Errorf("Got diff: %s", cmp.Diff(tt.expected, r))
+			if !cmp.Equal(tt.expected, r, cmp.AllowUnexported(repoURL{})) {
+				t.Errorf("Got diff: %s", cmp.Diff(tt.expected, r, cmp.AllowUnexported(repoURL{})))
+			}
+			if tt.uri != "" && r.URI() != tt.uri {
+				t.Errorf("repoURL.URI() = %v, want %v", r.URI(), tt.uri)
+			}
+		})
+	}
+}
+
+func TestRepoURL_IsValidRepoName(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		repo    string
+		wantErr error
+	}{
+		{
+			name: "Valid name",
+			repo: "scorecard_v4.go-1",
+		},
+		{
+			name:    "Name with a space",
+			repo:    "my repo",
+			wantErr: sce.ErrorInvalidGithubRepoName,
+		},
+		{
+			name:    "Single dot",
+			repo:    ".",
+			wantErr: sce.ErrorInvalidGithubRepoName,
+		},
+		{
+			name:    "Name too long",
+			repo:    strings.Repeat("a", 101),
+			wantErr: sce.ErrorInvalidGithubRepoName,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := repoURL{
+				host:  "github.com",
+				owner: "foo",
+				repo:  tt.repo,
+			}
+			if err := r.IsValid(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("repoURL.IsValid() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSuggestHost(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		host       string
+		suggestion string
+		ok         bool
+	}{
+		{
+			name:       "Typo",
+			host:       "github.con",
+			suggestion: "github.com",
+			ok:         true,
+		},
+		{
+			name:       "Top-level domain typo",
+			host:       "github.org",
+			suggestion: "github.com",
+			ok:         true,
+		},
+		{
+			name: "Exact match",
+			host: "github.com",
+		},
+		{
+			name: "Other real host",
+			host: "gitlab.com",
+		},
+		{
+			name: "GitHub Pages host",
+			host: "github.io",
+		},
+		{
+			name: "Enterprise host",
+			host: "github.mycorp",
+		},
+		{
+			name: "Unrelated host",
+			host: "example.org",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			suggestion, ok := SuggestHost(tt.host)
+			if suggestion != tt.suggestion || ok != tt.ok {
+				t.Errorf("SuggestHost() = (%v, %v), want (%v, %v)", suggestion, ok, tt.suggestion, tt.ok)
 			}
 		})
 	}
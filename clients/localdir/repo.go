@@ -20,9 +20,11 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"path"
+	"path/filepath"
+	"strings"
 
 	clients "github.com/ossf/scorecard/v4/clients"
+	sce "github.com/ossf/scorecard/v4/errors"
 )
 
 var errNotDirectory = errors.New("not a directory")
@@ -71,8 +73,17 @@ func (r *repoLocal) AppendMetadata(m ...string) {
 }
 
 // MakeLocalDirRepo returns an implementation of clients.Repo interface.
+// The input may carry a "file://" prefix and a leading "~", and relative
+// paths are resolved against the current working directory.
 func MakeLocalDirRepo(pathfn string) (clients.Repo, error) {
-	p := path.Clean(pathfn)
+	trimmed := strings.TrimPrefix(pathfn, "file://")
+	if trimmed == "" {
+		return nil, sce.WithMessage(sce.ErrorInvalidURL, fmt.Sprintf("%v. Expected a local directory", pathfn))
+	}
+	p, err := absPath(trimmed)
+	if err != nil {
+		return nil, err
+	}
 	repo := &repoLocal{
 		path: p,
 	}
@@ -82,3 +93,18 @@ func MakeLocalDirRepo(pathfn string) (clients.Repo, error) {
 	}
 	return repo, nil
 }
+
+func absPath(p string) (string, error) {
+	if p == "~" || strings.HasPrefix(p, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("os.UserHomeDir: %w", err)
+		}
+		p = filepath.Join(home, p[1:])
+	}
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return "", fmt.Errorf("filepath.Abs: %w", err)
+	}
+	return abs, nil
+}
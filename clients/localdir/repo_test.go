@@ -0,0 +1,87 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localdir
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+//nolint:paralleltest // Since t.Setenv is used.
+func TestMakeLocalDirRepo_Path(t *testing.T) {
+	home := t.TempDir()
+	if err := os.Mkdir(filepath.Join(home, "x"), 0o755); err != nil {
+		t.Fatalf("os.Mkdir: %v", err)
+	}
+	t.Setenv("HOME", home)
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("os.Getwd: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  error
+	}{
+		{
+			name:    "empty file URI",
+			input:   "file://",
+			wantErr: sce.ErrorInvalidURL,
+		},
+		{
+			name:     "relative file URI",
+			input:    "file://./testdata/repo0",
+			expected: filepath.Join(wd, "testdata", "repo0"),
+		},
+		{
+			name:     "tilde file URI",
+			input:    "file://~/x",
+			expected: filepath.Join(home, "x"),
+		},
+		{
+			name:     "absolute file URI",
+			input:    "file://" + filepath.Join(home, "x"),
+			expected: filepath.Join(home, "x"),
+		},
+		{
+			name:     "duplicate slashes",
+			input:    "file:///" + strings.ReplaceAll(filepath.Join(home, "x"), "/", "//"),
+			expected: filepath.Join(home, "x"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			repo, err := MakeLocalDirRepo(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MakeLocalDirRepo: %v, expected %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := repo.(*repoLocal).path; got != tt.expected {
+				t.Errorf("path = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -74,15 +74,15 @@ func processRequest(ctx context.Context,
 	var buffer bytes.Buffer
 	var buffer2 bytes.Buffer
 	// TODO: run Scorecard for each repo in a separate thread.
-	for _, repo := range batchRequest.GetRepos() {
-		logger.Info(fmt.Sprintf("Running Scorecard for repo: %s", *repo.Url))
-		repo, err := githubrepo.MakeGithubRepo(*repo.Url)
+	for _, repoReq := range batchRequest.GetRepos() {
+		logger.Info(fmt.Sprintf("Running Scorecard for repo: %s", *repoReq.Url))
+		repo, err := githubrepo.MakeGithubRepo(*repoReq.Url)
 		if err != nil {
 			// TODO(log): Previously Warn. Consider logging an error here.
 			logger.Info(fmt.Sprintf("invalid GitHub URL: %v", err))
 			continue
 		}
-		repo.AppendMetadata(repo.Metadata()...)
+		repo.AppendMetadata(repoReq.Metadata...)
 		result, err := pkg.RunScorecards(ctx, repo, clients.HeadSHA /*commitSHA*/, false /*raw*/, checksToRun,
 			repoClient, ossFuzzRepoClient, ciiClient, vulnsClient)
 		if errors.Is(err, sce.ErrRepoUnreachable) {
@@ -28,6 +28,12 @@ var (
 	ErrorUnsupportedHost = errors.New("unsupported host")
 	// ErrorInvalidURL indicates the repo's full URL was not passed.
 	ErrorInvalidURL = errors.New("invalid repo flag")
+	// ErrorMissingRepoName indicates the repo's URL points to a user or organization, not a repo.
+	ErrorMissingRepoName = fmt.Errorf("%w: missing repository name", ErrorInvalidURL)
+	// ErrorInvalidGithubOwner indicates the repo owner is not a valid GitHub user or organization name.
+	ErrorInvalidGithubOwner = fmt.Errorf("%w: invalid GitHub owner", ErrorInvalidURL)
+	// ErrorInvalidGithubRepoName indicates the repo name does not follow GitHub's naming rules.
+	ErrorInvalidGithubRepoName = errors.New("invalid GitHub repository name")
 	// ErrorShellParsing indicates there was an error when parsing shell code.
 	ErrorShellParsing = errors.New("error parsing shell code")
 	// ErrorUnsupportedCheck indicates check caanot be run for given request.